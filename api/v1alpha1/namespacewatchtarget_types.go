@@ -0,0 +1,100 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigMapRef identifies the ConfigMap key a NamespaceWatchTarget's
+// namespace list is written to.
+type ConfigMapRef struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+	// Key within the ConfigMap's data to hold the rendered namespace list.
+	Key string `json:"key"`
+}
+
+// ReloadTarget identifies the workload to reload when the namespace list changes.
+type ReloadTarget struct {
+	// Kind of the workload, e.g. Deployment or StatefulSet.
+	Kind string `json:"kind"`
+	// Name of the workload.
+	Name string `json:"name"`
+	// Namespace of the workload.
+	Namespace string `json:"namespace"`
+}
+
+// NamespaceWatchTargetSpec defines the desired state of NamespaceWatchTarget.
+type NamespaceWatchTargetSpec struct {
+	// LabelSelector selects which namespaces this target watches.
+	LabelSelector string `json:"labelSelector"`
+
+	// ConfigMapRef is where the watched namespace list is published.
+	ConfigMapRef ConfigMapRef `json:"configMapRef"`
+
+	// ReloadTarget is reloaded whenever the published namespace list changes.
+	ReloadTarget ReloadTarget `json:"reloadTarget"`
+
+	// NamespaceNameFilter further restricts matched namespaces by name via a
+	// regular expression, applied after LabelSelector.
+	// +optional
+	NamespaceNameFilter string `json:"namespaceNameFilter,omitempty"`
+
+	// OutputKind selects where the rendered namespace list is written: configmap
+	// or secret. Defaults to configmap.
+	// +optional
+	OutputKind string `json:"outputKind,omitempty"`
+
+	// OutputFormat selects how the namespace list is rendered: csv, json, yaml,
+	// or gotemplate. Defaults to csv.
+	// +optional
+	OutputFormat string `json:"outputFormat,omitempty"`
+
+	// OutputTemplate is the Go template used to render the namespace list when
+	// OutputFormat is gotemplate.
+	// +optional
+	OutputTemplate string `json:"outputTemplate,omitempty"`
+}
+
+// NamespaceWatchTargetStatus defines the observed state of NamespaceWatchTarget.
+type NamespaceWatchTargetStatus struct {
+	// ObservedNamespaces is the namespace list last written to ConfigMapRef.
+	// +optional
+	ObservedNamespaces []string `json:"observedNamespaces,omitempty"`
+
+	// LastUpdateTime is when ObservedNamespaces was last written.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Selector",type=string,JSONPath=`.spec.labelSelector`
+// +kubebuilder:printcolumn:name="ConfigMap",type=string,JSONPath=`.spec.configMapRef.name`
+
+// NamespaceWatchTarget declares one set of namespaces to watch and where to
+// publish the resulting namespace list, so a single helper deployment can
+// serve multiple Vault operator instances with different namespace slicing
+// rules.
+type NamespaceWatchTarget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NamespaceWatchTargetSpec   `json:"spec,omitempty"`
+	Status NamespaceWatchTargetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceWatchTargetList contains a list of NamespaceWatchTarget.
+type NamespaceWatchTargetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespaceWatchTarget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespaceWatchTarget{}, &NamespaceWatchTargetList{})
+}
@@ -0,0 +1,287 @@
+// Package controllers contains the reconcilers for this helper's custom resources.
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	vaultv1alpha1 "github.com/hsiaoairplane/vault-operator-helper/api/v1alpha1"
+	"github.com/hsiaoairplane/vault-operator-helper/internal/debounce"
+	"github.com/hsiaoairplane/vault-operator-helper/internal/health"
+	"github.com/hsiaoairplane/vault-operator-helper/internal/output"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceWatchTargetReconciler reconciles a NamespaceWatchTarget by running
+// one dedicated namespace informer per CR and keeping its target ConfigMap in
+// sync with the namespaces that informer selects.
+type NamespaceWatchTargetReconciler struct {
+	client.Client
+	Clientset *kubernetes.Clientset
+
+	mu       sync.Mutex
+	watchers map[types.NamespacedName]context.CancelFunc
+	specHash map[types.NamespacedName]string
+}
+
+// +kubebuilder:rbac:groups=vault.hsiaoairplane.io,resources=namespacewatchtargets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vault.hsiaoairplane.io,resources=namespacewatchtargets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;patch
+
+// Reconcile starts (or restarts, on spec change) the per-target informer for
+// a NamespaceWatchTarget, and tears it down once the CR is deleted.
+func (r *NamespaceWatchTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.mu.Lock()
+	if r.watchers == nil {
+		r.watchers = make(map[types.NamespacedName]context.CancelFunc)
+	}
+	if r.specHash == nil {
+		r.specHash = make(map[types.NamespacedName]string)
+	}
+	r.mu.Unlock()
+
+	var target vaultv1alpha1.NamespaceWatchTarget
+	if err := r.Get(ctx, req.NamespacedName, &target); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.stopWatcher(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Only restart the watcher when the spec actually changed; otherwise a
+	// periodic resync would needlessly tear down and rebuild the informer,
+	// workqueue, and goroutines on every pass.
+	hash, err := hashSpec(target.Spec)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("hashing spec for %s: %w", req.NamespacedName, err)
+	}
+
+	r.mu.Lock()
+	unchanged := r.specHash[req.NamespacedName] == hash
+	r.mu.Unlock()
+	if unchanged {
+		return ctrl.Result{}, nil
+	}
+
+	r.stopWatcher(req.NamespacedName)
+	r.startWatcher(req.NamespacedName, target.Spec.DeepCopy())
+
+	r.mu.Lock()
+	r.specHash[req.NamespacedName] = hash
+	r.mu.Unlock()
+
+	return ctrl.Result{}, nil
+}
+
+// hashSpec returns a stable digest of spec so Reconcile can tell a real spec
+// change from controller-runtime's periodic resync.
+func hashSpec(spec vaultv1alpha1.NamespaceWatchTargetSpec) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%x", sum), nil
+}
+
+func (r *NamespaceWatchTargetReconciler) stopWatcher(name types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cancel, ok := r.watchers[name]; ok {
+		cancel()
+		delete(r.watchers, name)
+	}
+	delete(r.specHash, name)
+	health.NamespacesTrackedByTarget.DeleteLabelValues(name.String())
+}
+
+func (r *NamespaceWatchTargetReconciler) startWatcher(name types.NamespacedName, spec *vaultv1alpha1.NamespaceWatchTargetSpec) {
+	watchCtx, cancel := context.WithCancel(context.Background())
+
+	r.mu.Lock()
+	r.watchers[name] = cancel
+	r.mu.Unlock()
+
+	var nameFilter *regexp.Regexp
+	if spec.NamespaceNameFilter != "" {
+		var err error
+		nameFilter, err = regexp.Compile(spec.NamespaceNameFilter)
+		if err != nil {
+			fmt.Printf("NamespaceWatchTarget %s: invalid namespaceNameFilter %q: %v\n", name, spec.NamespaceNameFilter, err)
+			return
+		}
+	}
+
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(r.Clientset, time.Minute, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+		opts.LabelSelector = spec.LabelSelector
+	}))
+	nsInformer := informerFactory.Core().V1().Namespaces().Informer()
+
+	reconcile := func() {
+		observed, changed, err := r.reconcileConfigMap(context.Background(), name, *spec, nameFilter)
+		if err != nil {
+			health.ConfigMapUpdateErrorsTotal.Inc()
+			fmt.Printf("Error reconciling NamespaceWatchTarget %s: %v\n", name, err)
+			return
+		}
+
+		health.RecordSuccessfulUpdate()
+		health.MarkFirstUpdateSucceeded()
+
+		if err := r.updateStatus(context.Background(), name, observed); err != nil {
+			fmt.Printf("Error updating status for NamespaceWatchTarget %s: %v\n", name, err)
+		}
+
+		if !changed {
+			return
+		}
+		health.ConfigMapUpdatesTotal.Inc()
+
+		health.ReloadsTotal.Inc()
+		if err := r.reloadTarget(context.Background(), spec.ReloadTarget); err != nil {
+			health.ReloadErrorsTotal.Inc()
+			fmt.Printf("Error reloading target for NamespaceWatchTarget %s: %v\n", name, err)
+		}
+	}
+
+	// Coalesce bursts of informer events onto a debounced workqueue, the same
+	// way the legacy single-target path does, so e.g. a `kubectl apply -f` of
+	// many namespaces triggers one reconcile instead of one per event.
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	enqueue := func() { queue.Add(debounce.Key) }
+
+	nsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { enqueue() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj == newObj {
+				health.InformerResyncsTotal.Inc()
+			}
+			enqueue()
+		},
+		DeleteFunc: func(obj interface{}) { enqueue() },
+	})
+
+	stopCh := watchCtx.Done()
+	go func() {
+		informerFactory.Start(stopCh)
+		informerFactory.WaitForCacheSync(stopCh)
+		health.MarkCacheSynced()
+		reconcile()
+		go debounce.RunWorker(queue, stopCh, reconcile)
+		<-stopCh
+		informerFactory.Shutdown()
+	}()
+}
+
+// reconcileConfigMap renders the namespaces currently selected by spec per
+// spec.OutputFormat and writes them to spec.ConfigMapRef, as a ConfigMap or
+// Secret depending on spec.OutputKind, creating it if necessary. It reports
+// whether the rendered value changed so the caller knows whether to reload
+// spec.ReloadTarget.
+func (r *NamespaceWatchTargetReconciler) reconcileConfigMap(ctx context.Context, name types.NamespacedName, spec vaultv1alpha1.NamespaceWatchTargetSpec, nameFilter *regexp.Regexp) ([]string, bool, error) {
+	namespaces, err := r.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: spec.LabelSelector})
+	if err != nil {
+		return nil, false, fmt.Errorf("listing namespaces for %s/%s: %w", spec.ConfigMapRef.Namespace, spec.ConfigMapRef.Name, err)
+	}
+
+	names := []string{}
+	for _, ns := range namespaces.Items {
+		if nameFilter != nil && !nameFilter.MatchString(ns.Name) {
+			continue
+		}
+		names = append(names, ns.Name)
+	}
+	health.NamespacesTrackedByTarget.WithLabelValues(name.String()).Set(float64(len(names)))
+
+	newValue, err := output.Render(spec.OutputFormat, spec.OutputTemplate, names)
+	if err != nil {
+		return nil, false, fmt.Errorf("rendering namespace list for %s/%s: %w", spec.ConfigMapRef.Namespace, spec.ConfigMapRef.Name, err)
+	}
+
+	var changed bool
+	if spec.OutputKind == "secret" {
+		changed, err = output.WriteSecret(ctx, r.Clientset, spec.ConfigMapRef.Namespace, spec.ConfigMapRef.Name, spec.ConfigMapRef.Key, newValue)
+	} else {
+		changed, err = output.WriteConfigMap(ctx, r.Clientset, spec.ConfigMapRef.Namespace, spec.ConfigMapRef.Name, spec.ConfigMapRef.Key, newValue)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return names, changed, nil
+}
+
+// updateStatus records the namespaces last written for name's target and
+// when, so `kubectl get namespacewatchtarget` reflects reality instead of
+// leaving status permanently empty.
+func (r *NamespaceWatchTargetReconciler) updateStatus(ctx context.Context, name types.NamespacedName, observed []string) error {
+	var target vaultv1alpha1.NamespaceWatchTarget
+	if err := r.Get(ctx, name, &target); err != nil {
+		return fmt.Errorf("getting %s for status update: %w", name, err)
+	}
+
+	target.Status.ObservedNamespaces = observed
+	now := metav1.Now()
+	target.Status.LastUpdateTime = &now
+
+	if err := r.Status().Update(ctx, &target); err != nil {
+		return fmt.Errorf("updating status for %s: %w", name, err)
+	}
+	return nil
+}
+
+// reloadTarget reloads spec.ReloadTarget after its ConfigMap has changed,
+// mirroring the legacy path's rollout/annotation reload strategies: a
+// Deployment or StatefulSet is rolled via a restartedAt annotation patch on
+// its pod template, anything else is treated as a Pod to annotate directly.
+func (r *NamespaceWatchTargetReconciler) reloadTarget(ctx context.Context, target vaultv1alpha1.ReloadTarget) error {
+	restartedAt := time.Now().Format(time.RFC3339)
+
+	switch target.Kind {
+	case "Deployment":
+		patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"vault-operator-helper/restartedAt":%q}}}}}`, restartedAt))
+		_, err := r.Clientset.AppsV1().Deployments(target.Namespace).Patch(ctx, target.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("patching deployment %s/%s: %w", target.Namespace, target.Name, err)
+		}
+	case "StatefulSet":
+		patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"vault-operator-helper/restartedAt":%q}}}}}`, restartedAt))
+		_, err := r.Clientset.AppsV1().StatefulSets(target.Namespace).Patch(ctx, target.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("patching statefulset %s/%s: %w", target.Namespace, target.Name, err)
+		}
+	default:
+		patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{"vault-operator-helper/restartedAt":%q}}}`, restartedAt))
+		_, err := r.Clientset.CoreV1().Pods(target.Namespace).Patch(ctx, target.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("patching pod %s/%s: %w", target.Namespace, target.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SetupWithManager wires the reconciler into the controller-runtime manager.
+func (r *NamespaceWatchTargetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vaultv1alpha1.NamespaceWatchTarget{}).
+		Complete(r)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	vaultv1alpha1 "github.com/hsiaoairplane/vault-operator-helper/api/v1alpha1"
+	"github.com/hsiaoairplane/vault-operator-helper/controllers"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+var enableCRDTargets bool
+
+func init() {
+	flag.BoolVar(&enableCRDTargets, "enable-crd-targets", false,
+		"Watch NamespaceWatchTarget custom resources instead of the legacy single-target flags (--label-selector, --configmap-name, ...)")
+
+	if err := vaultv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("adding vault.hsiaoairplane.io/v1alpha1 to scheme: %v", err))
+	}
+}
+
+// runCRDController starts a controller-runtime manager that reconciles
+// NamespaceWatchTarget CRs, each driving its own namespace informer and
+// ConfigMap. It blocks until ctx is cancelled.
+func runCRDController(ctx context.Context) error {
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme: scheme.Scheme,
+		// The health package already serves /metrics on --health-addr; disable
+		// the manager's own metrics server so it doesn't fight it for :8080.
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		return fmt.Errorf("creating controller manager: %w", err)
+	}
+
+	reconciler := &controllers.NamespaceWatchTargetReconciler{
+		Client:    mgr.GetClient(),
+		Clientset: clientset,
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("setting up NamespaceWatchTarget controller: %w", err)
+	}
+
+	fmt.Println("Starting NamespaceWatchTarget controller manager...")
+	return mgr.Start(ctx)
+}
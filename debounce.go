@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/hsiaoairplane/vault-operator-helper/internal/debounce"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// debounceKey is the single workqueue item namespace events coalesce onto;
+// its value doesn't matter, only its presence.
+const debounceKey = debounce.Key
+
+// runDebouncedWorker drains queue and coalesces bursts of events into a
+// single updateConfigMap call, per --debounce-interval/--max-debounce.
+func runDebouncedWorker(queue workqueue.RateLimitingInterface, stopCh <-chan struct{}) {
+	debounce.RunWorker(queue, stopCh, updateConfigMap)
+}
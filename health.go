@@ -0,0 +1,18 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/hsiaoairplane/vault-operator-helper/internal/health"
+)
+
+var healthAddr string
+
+func init() {
+	flag.StringVar(&healthAddr, "health-addr", ":8080", "Address to serve /healthz, /readyz, and /metrics on")
+}
+
+// startHealthServer serves /healthz, /readyz, and /metrics on healthAddr.
+func startHealthServer() {
+	health.StartServer(healthAddr)
+}
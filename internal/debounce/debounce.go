@@ -0,0 +1,85 @@
+// Package debounce coalesces bursts of workqueue events into a single sync
+// call, shared by every run mode (legacy single-target and CRD-driven) so a
+// burst of namespace events against any target does one List+Update instead
+// of one per event.
+package debounce
+
+import (
+	"flag"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+var (
+	// Interval is how long to wait for events to settle before running sync.
+	Interval time.Duration
+	// Max bounds how long a continuous burst of events can keep deferring a
+	// sync.
+	Max time.Duration
+)
+
+func init() {
+	flag.DurationVar(&Interval, "debounce-interval", 2*time.Second, "How long to wait for namespace events to settle before running one sync")
+	flag.DurationVar(&Max, "max-debounce", 30*time.Second, "Upper bound on how long a continuous burst of events can keep deferring a sync")
+}
+
+// Key is the single workqueue item events coalesce onto; its value doesn't
+// matter, only its presence.
+const Key = "sync"
+
+// RunWorker drains queue and coalesces bursts of events into a single sync
+// call: each event (re)starts an Interval timer, except once Max has elapsed
+// since the burst began, in which case the pending timer is left to fire so
+// a continuous stream of events can't defer updates forever.
+func RunWorker(queue workqueue.RateLimitingInterface, stopCh <-chan struct{}, sync func()) {
+	go func() {
+		<-stopCh
+		queue.ShutDown()
+	}()
+
+	events := make(chan struct{})
+	go func() {
+		defer close(events)
+		for {
+			key, shutdown := queue.Get()
+			if shutdown {
+				return
+			}
+			queue.Done(key)
+			queue.Forget(key)
+			events <- struct{}{}
+		}
+	}()
+
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	var windowStart time.Time
+
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			switch {
+			case timer == nil:
+				windowStart = time.Now()
+				timer = time.NewTimer(Interval)
+				timerC = timer.C
+			case time.Since(windowStart) < Max:
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(Interval)
+			}
+			// else: Max has elapsed for this burst; let the
+			// already-running timer fire instead of resetting again.
+
+		case <-timerC:
+			sync()
+			timer = nil
+			timerC = nil
+		}
+	}
+}
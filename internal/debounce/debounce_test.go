@@ -0,0 +1,64 @@
+package debounce
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestRunWorkerCoalescesBurst(t *testing.T) {
+	origInterval, origMax := Interval, Max
+	Interval, Max = 20*time.Millisecond, 200*time.Millisecond
+	defer func() { Interval, Max = origInterval, origMax }()
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	stopCh := make(chan struct{})
+
+	var syncs int32
+	go RunWorker(queue, stopCh, func() { atomic.AddInt32(&syncs, 1) })
+
+	// A burst of events within Interval should coalesce into one sync.
+	for i := 0; i < 5; i++ {
+		queue.Add(Key)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	time.Sleep(3 * Interval)
+	close(stopCh)
+
+	if got := atomic.LoadInt32(&syncs); got != 1 {
+		t.Fatalf("syncs = %d, want 1", got)
+	}
+}
+
+func TestRunWorkerBoundsContinuousBurst(t *testing.T) {
+	origInterval, origMax := Interval, Max
+	Interval, Max = 20*time.Millisecond, 60*time.Millisecond
+	defer func() { Interval, Max = origInterval, origMax }()
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	stopCh := make(chan struct{})
+
+	var syncs int32
+	go RunWorker(queue, stopCh, func() { atomic.AddInt32(&syncs, 1) })
+
+	// Keep re-enqueueing faster than Interval for longer than Max; Max should
+	// force at least one sync instead of deferring forever.
+	deadline := time.Now().Add(150 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		queue.Add(Key)
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Read the count before the burst's trailing Interval can elapse, so only a
+	// sync forced by Max is counted.
+	got := atomic.LoadInt32(&syncs)
+
+	time.Sleep(3 * Interval)
+	close(stopCh)
+
+	if got < 1 {
+		t.Fatalf("syncs = %d, want at least 1 within the continuous burst", got)
+	}
+}
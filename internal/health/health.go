@@ -0,0 +1,160 @@
+// Package health holds the liveness/readiness state and Prometheus metrics
+// shared by every run mode (legacy single-target, leader-elected, and
+// CRD-driven), so /healthz, /readyz, and /metrics reflect reality regardless
+// of which reconciliation path is producing the updates.
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics exposed on /metrics.
+var (
+	NamespacesTracked = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "vault_operator_helper_namespaces_tracked",
+		Help: "Current number of namespaces matched by the watch selector(s). Only meaningful in single-target mode; see vault_operator_helper_namespaces_tracked_by_target for CRD mode.",
+	})
+	NamespacesTrackedByTarget = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vault_operator_helper_namespaces_tracked_by_target",
+		Help: "Current number of namespaces matched by each NamespaceWatchTarget's selector(s).",
+	}, []string{"target"})
+	ConfigMapUpdatesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "vault_operator_helper_configmap_updates_total",
+		Help: "Total number of successful ConfigMap/Secret updates.",
+	})
+	ConfigMapUpdateErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "vault_operator_helper_configmap_update_errors_total",
+		Help: "Total number of failed ConfigMap/Secret updates.",
+	})
+	ReloadsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "vault_operator_helper_reloads_total",
+		Help: "Total number of main container reload/restart invocations.",
+	})
+	ReloadErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "vault_operator_helper_reload_errors_total",
+		Help: "Total number of failed main container reload/restart invocations.",
+	})
+	InformerResyncsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "vault_operator_helper_informer_resyncs_total",
+		Help: "Total number of namespace informer resync events.",
+	})
+	secondsSinceLastUpdate = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "vault_operator_helper_seconds_since_last_successful_update",
+		Help: "Seconds since the last successful ConfigMap/Secret update, or -1 before the first one.",
+	}, func() float64 {
+		lastSuccessfulUpdate.RLock()
+		defer lastSuccessfulUpdate.RUnlock()
+		if lastSuccessfulUpdate.at.IsZero() {
+			return -1
+		}
+		return time.Since(lastSuccessfulUpdate.at).Seconds()
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		NamespacesTracked,
+		NamespacesTrackedByTarget,
+		ConfigMapUpdatesTotal,
+		ConfigMapUpdateErrorsTotal,
+		ReloadsTotal,
+		ReloadErrorsTotal,
+		InformerResyncsTotal,
+		secondsSinceLastUpdate,
+	)
+}
+
+var lastSuccessfulUpdate struct {
+	sync.RWMutex
+	at time.Time
+}
+
+// RecordSuccessfulUpdate marks now as the time of the latest successful
+// ConfigMap/Secret reconciliation, from any run mode.
+func RecordSuccessfulUpdate() {
+	lastSuccessfulUpdate.Lock()
+	defer lastSuccessfulUpdate.Unlock()
+	lastSuccessfulUpdate.at = time.Now()
+}
+
+// state tracks the milestones /healthz and /readyz report on.
+var state struct {
+	sync.RWMutex
+	kubeConfigReady bool
+	cacheSynced     bool
+	firstUpdateDone bool
+}
+
+// MarkKubeConfigReady records that a Kubernetes config/client was obtained.
+func MarkKubeConfigReady() {
+	state.Lock()
+	defer state.Unlock()
+	state.kubeConfigReady = true
+}
+
+// MarkCacheSynced records that at least one informer has finished its
+// initial list+watch sync. Call once per informer in multi-target modes;
+// readiness only requires the first to have synced.
+func MarkCacheSynced() {
+	state.Lock()
+	defer state.Unlock()
+	state.cacheSynced = true
+}
+
+// MarkFirstUpdateSucceeded records that at least one reconciliation pass has
+// completed without error.
+func MarkFirstUpdateSucceeded() {
+	state.Lock()
+	defer state.Unlock()
+	state.firstUpdateDone = true
+}
+
+// IsHealthy reports whether /healthz should return 200.
+func IsHealthy() bool {
+	state.RLock()
+	defer state.RUnlock()
+	return state.kubeConfigReady
+}
+
+// IsReady reports whether /readyz should return 200.
+func IsReady() bool {
+	state.RLock()
+	defer state.RUnlock()
+	return state.cacheSynced && state.firstUpdateDone
+}
+
+// StartServer serves /healthz, /readyz, and /metrics on addr so the pod can
+// use standard Kubernetes probes and be scraped by kube-prometheus.
+func StartServer(addr string) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if !IsHealthy() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !IsReady() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			fmt.Printf("Error serving health/metrics endpoints: %v\n", err)
+		}
+	}()
+}
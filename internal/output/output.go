@@ -0,0 +1,115 @@
+// Package output renders a watched namespace list and writes it to a
+// ConfigMap or Secret, shared by every run mode (legacy single-target,
+// leader-elected, and CRD-driven) so output-kind/output-format support
+// doesn't have to be reimplemented per reconciliation path.
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// Render renders namespaces per format (csv, json, yaml, or gotemplate),
+// using tmpl as the Go template source when format is "gotemplate". An
+// empty format is treated as csv.
+func Render(format, tmpl string, namespaces []string) (string, error) {
+	switch format {
+	case "", "csv":
+		return strings.Join(namespaces, ","), nil
+	case "json":
+		b, err := json.Marshal(namespaces)
+		if err != nil {
+			return "", fmt.Errorf("marshaling namespace list as JSON: %w", err)
+		}
+		return string(b), nil
+	case "yaml":
+		b, err := yaml.Marshal(namespaces)
+		if err != nil {
+			return "", fmt.Errorf("marshaling namespace list as YAML: %w", err)
+		}
+		return string(b), nil
+	case "gotemplate":
+		if tmpl == "" {
+			return "", fmt.Errorf("a template is required when format=gotemplate")
+		}
+		t, err := template.New("output").Parse(tmpl)
+		if err != nil {
+			return "", fmt.Errorf("parsing output template: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, namespaces); err != nil {
+			return "", fmt.Errorf("executing output template: %w", err)
+		}
+		return buf.String(), nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, must be one of: csv, json, yaml, gotemplate", format)
+	}
+}
+
+// WriteConfigMap creates or updates the ConfigMap at namespace/name with
+// value at key, creating it if necessary, and reports whether the stored
+// value actually changed.
+func WriteConfigMap(ctx context.Context, clientset *kubernetes.Clientset, namespace, name, key, value string) (bool, error) {
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		_, err = clientset.CoreV1().ConfigMaps(namespace).Create(ctx, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       map[string]string{key: value},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, fmt.Errorf("creating ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		return true, nil
+	}
+
+	if cm.Data[key] == value {
+		return false, nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[key] = value
+	if _, err := clientset.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return false, fmt.Errorf("updating ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return true, nil
+}
+
+// WriteSecret creates or updates the Secret at namespace/name with value at
+// key, creating it if necessary, and reports whether the stored value
+// actually changed. Secret data is base64-encoded by the API server, so it's
+// compared as a string here.
+func WriteSecret(ctx context.Context, clientset *kubernetes.Clientset, namespace, name, key, value string) (bool, error) {
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		_, err = clientset.CoreV1().Secrets(namespace).Create(ctx, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       map[string][]byte{key: []byte(value)},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, fmt.Errorf("creating Secret %s/%s: %w", namespace, name, err)
+		}
+		return true, nil
+	}
+
+	if string(secret.Data[key]) == value {
+		return false, nil
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[key] = []byte(value)
+	if _, err := clientset.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return false, fmt.Errorf("updating Secret %s/%s: %w", namespace, name, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,43 @@
+package output
+
+import "testing"
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		name    string
+		format  string
+		tmpl    string
+		ns      []string
+		want    string
+		wantErr bool
+	}{
+		{name: "default format is csv", format: "", ns: []string{"a", "b"}, want: "a,b"},
+		{name: "csv", format: "csv", ns: []string{"a", "b", "c"}, want: "a,b,c"},
+		{name: "csv empty", format: "csv", ns: []string{}, want: ""},
+		{name: "json", format: "json", ns: []string{"a", "b"}, want: `["a","b"]`},
+		{name: "json empty list is not null", format: "json", ns: []string{}, want: "[]"},
+		{name: "yaml", format: "yaml", ns: []string{"a"}, want: "- a\n"},
+		{name: "yaml empty list is not null", format: "yaml", ns: []string{}, want: "[]\n"},
+		{name: "gotemplate", format: "gotemplate", tmpl: "{{range .}}{{.}};{{end}}", ns: []string{"a", "b"}, want: "a;b;"},
+		{name: "gotemplate without template errors", format: "gotemplate", ns: []string{"a"}, wantErr: true},
+		{name: "unknown format errors", format: "bogus", ns: []string{"a"}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Render(tc.format, tc.tmpl, tc.ns)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Render() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("Render() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+var (
+	enableLeaderElection bool
+	leaderElectLeaseName string
+	leaseDuration        time.Duration
+	renewDeadline        time.Duration
+	retryPeriod          time.Duration
+)
+
+func init() {
+	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false, "Enable leader election so only one replica watches namespaces and updates the ConfigMap")
+	flag.StringVar(&leaderElectLeaseName, "leader-elect-lease-name", "vault-operator-helper-leader", "Name of the Lease used for leader election")
+	flag.DurationVar(&leaseDuration, "lease-duration", 15*time.Second, "Duration non-leader candidates wait before forcing acquisition")
+	flag.DurationVar(&renewDeadline, "renew-deadline", 10*time.Second, "Duration the leader retries refreshing its lease before giving it up")
+	flag.DurationVar(&retryPeriod, "retry-period", 2*time.Second, "Duration leader election clients wait between tries of actions")
+}
+
+// runWithLeaderElection blocks, running the namespace watcher only while this
+// replica holds the Lease in configMapNamespace. ReleaseOnCancel makes the
+// leader actively patch the Lease's holderIdentity to empty and its
+// renewTime/acquireTime into the past as soon as ctx is cancelled, so the
+// next replica can acquire it immediately instead of waiting leaseDuration.
+func runWithLeaderElection(ctx context.Context) {
+	id, err := os.Hostname()
+	if err != nil {
+		id = "vault-operator-helper"
+	}
+	id = fmt.Sprintf("%s_%s", id, uuid.NewUUID())
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaderElectLeaseName,
+			Namespace: configMapNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: id,
+		},
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				fmt.Println("Acquired leader lease, starting namespace watcher...")
+
+				watcherStopCh := make(chan struct{})
+				go func() {
+					<-leaderCtx.Done()
+					close(watcherStopCh)
+				}()
+
+				updateConfigMap()
+				watchNamespaces(watcherStopCh)
+			},
+			OnStoppedLeading: func() {
+				fmt.Printf("%s no longer the leader, namespace watcher paused\n", id)
+			},
+			OnNewLeader: func(identity string) {
+				if identity == id {
+					return
+				}
+				fmt.Printf("New leader elected: %s\n", identity)
+			},
+		},
+	})
+}
@@ -5,21 +5,21 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
 
-	v1 "k8s.io/api/core/v1"
+	"github.com/hsiaoairplane/vault-operator-helper/internal/health"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
 )
 
 var (
@@ -40,17 +40,18 @@ func init() {
 	flag.StringVar(&labelSelector, "label-selector", "foo=bar", "Label selector for namespaces to watch")
 	flag.StringVar(&watchKey, "watch-key", "WATCH_NAMESPACE", "Key in the ConfigMap to store namespace list")
 	flag.StringVar(&mainContainerName, "main-container", "main-container", "Name of the main container to restart")
-
-	// Parse flags
-	flag.Parse()
 }
 
 func main() {
+	// Parse flags once all init() functions across the package have registered theirs
+	flag.Parse()
+
 	config, err := getKubeConfig()
 	if err != nil {
 		fmt.Printf("Error getting Kubernetes config: %v\n", err)
 		os.Exit(1)
 	}
+	health.MarkKubeConfigReady()
 
 	clientset, err = kubernetes.NewForConfig(config)
 	if err != nil {
@@ -58,24 +59,51 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Ensure the ConfigMap is created/updated at startup
-	updateConfigMap()
+	reloader, err = newReloadStrategy()
+	if err != nil {
+		fmt.Printf("Error configuring reload strategy: %v\n", err)
+		os.Exit(1)
+	}
+
+	startHealthServer()
 
 	// Set up signal handling for graceful shutdown
-	stopCh := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
 	signalCh := make(chan os.Signal, 1)
-
-	// Listen for termination signals
-	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT)
-
-	// Listen for termination signals
 	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT)
 
 	go func() {
 		<-signalCh
 		fmt.Println("Received termination signal. Shutting down gracefully...")
-		close(stopCh) // Stop the namespace watcher
-		os.Exit(0)    // Exit the program
+		cancel()
+	}()
+
+	if enableCRDTargets {
+		// Declarative, multi-tenant mode: one informer/ConfigMap per
+		// NamespaceWatchTarget CR, reconciled by a controller-runtime manager.
+		if err := runCRDController(ctx); err != nil {
+			fmt.Printf("Error running NamespaceWatchTarget controller: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if enableLeaderElection {
+		// Only the elected leader runs watchNamespaces/updateConfigMap; this
+		// call blocks until ctx is cancelled and the Lease has been released.
+		runWithLeaderElection(ctx)
+		return
+	}
+
+	// Legacy single-target fallback: the flags from init() (--label-selector,
+	// --configmap-name, ...) drive one ConfigMap instead of a CRD.
+	// Ensure the ConfigMap is created/updated at startup
+	updateConfigMap()
+
+	stopCh := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(stopCh)
 	}()
 
 	// Start namespace watcher
@@ -110,24 +138,39 @@ func getKubeConfig() (*rest.Config, error) {
 	return config, nil
 }
 
-// watchNamespaces monitors namespace creation and deletion
+// watchNamespaces monitors namespace creation and deletion, coalescing
+// bursts of events onto a debounced workqueue so e.g. a `kubectl apply -f` of
+// many namespaces triggers one updateConfigMap instead of one per event.
 func watchNamespaces(stopCh chan struct{}) {
 	informerFactory := informers.NewSharedInformerFactoryWithOptions(clientset, time.Minute, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
 		opts.LabelSelector = labelSelector
 	}))
 	namespaceInformer := informerFactory.Core().V1().Namespaces().Informer()
 
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	enqueue := func() { queue.Add(debounceKey) }
+
 	namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(obj interface{}) { updateConfigMap() },
-		UpdateFunc: func(oldObj, newObj interface{}) { updateConfigMap() },
-		DeleteFunc: func(obj interface{}) { updateConfigMap() },
+		AddFunc: func(obj interface{}) { enqueue() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj == newObj {
+				health.InformerResyncsTotal.Inc()
+			}
+			enqueue()
+		},
+		DeleteFunc: func(obj interface{}) { enqueue() },
 	})
 
 	informerFactory.Start(stopCh)
 	informerFactory.WaitForCacheSync(stopCh)
+	health.MarkCacheSynced()
+
+	go runDebouncedWorker(queue, stopCh)
 }
 
-// updateConfigMap updates the ConfigMap with the latest namespace list
+// updateConfigMap renders the filtered namespace list per --output-format and
+// writes it to the configured --output-kind, triggering a reload only when
+// the rendered value actually changed.
 func updateConfigMap() {
 	lock.Lock()
 	defer lock.Unlock()
@@ -138,31 +181,41 @@ func updateConfigMap() {
 		return
 	}
 
-	newValue := strings.Join(namespaces, ",")
+	health.NamespacesTracked.Set(float64(len(namespaces)))
 
-	// Fetch the existing ConfigMap
-	cm, err := clientset.CoreV1().ConfigMaps(configMapNamespace).Get(context.TODO(), configMapName, metav1.GetOptions{})
+	newValue, err := renderNamespaces(namespaces)
 	if err != nil {
-		// If ConfigMap doesn't exist, create it
-		createConfigMap(newValue)
+		fmt.Printf("Error rendering namespace list: %v\n", err)
 		return
 	}
 
-	// Check if the value has changed
-	if cm.Data[watchKey] == newValue {
+	var changed bool
+	switch outputKind {
+	case "secret":
+		changed, err = writeSecret(newValue)
+	default:
+		changed, err = writeConfigMap(newValue)
+	}
+	if err != nil {
+		health.ConfigMapUpdateErrorsTotal.Inc()
+		fmt.Printf("Error writing output: %v\n", err)
 		return
 	}
 
-	// Update ConfigMap
-	cm.Data[watchKey] = newValue
-	_, err = clientset.CoreV1().ConfigMaps(configMapNamespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
-	if err != nil {
-		fmt.Printf("Error updating ConfigMap: %v\n", err)
+	health.RecordSuccessfulUpdate()
+	health.MarkFirstUpdateSucceeded()
+
+	if !changed {
 		return
 	}
+	health.ConfigMapUpdatesTotal.Inc()
 
-	// Trigger restart of the main container
-	killMainContainer()
+	// Trigger a reload of the main container via the configured strategy
+	health.ReloadsTotal.Inc()
+	if err := reloader.Reload(context.TODO()); err != nil {
+		health.ReloadErrorsTotal.Inc()
+		fmt.Printf("Error reloading main container: %v\n", err)
+	}
 }
 
 // getFilteredNamespaces retrieves namespaces with the specified label
@@ -174,60 +227,9 @@ func getFilteredNamespaces() ([]string, error) {
 		return nil, err
 	}
 
-	var nsList []string
+	nsList := []string{}
 	for _, ns := range namespaces.Items {
 		nsList = append(nsList, ns.Name)
 	}
 	return nsList, nil
 }
-
-// createConfigMap creates a new ConfigMap
-func createConfigMap(value string) {
-	cm := &v1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      configMapName,
-			Namespace: configMapNamespace,
-		},
-		Data: map[string]string{
-			watchKey: value,
-		},
-	}
-
-	_, err := clientset.CoreV1().ConfigMaps(configMapNamespace).Create(context.TODO(), cm, metav1.CreateOptions{})
-	if err != nil {
-		fmt.Printf("Error creating ConfigMap: %v\n", err)
-	}
-}
-
-// killMainContainer kills the main container to reload the ConfigMap
-func killMainContainer() {
-	fmt.Println("Restarting main container...")
-
-	// Find the main container's PID (assumes PID namespace is shared)
-	pid, err := getMainContainerPID()
-	if err != nil {
-		fmt.Printf("Error getting main container PID: %v\n", err)
-		return
-	}
-
-	// Send SIGTERM to the main container
-	err = exec.Command("kill", "-SIGTERM", pid).Run()
-	if err != nil {
-		fmt.Printf("Error sending SIGTERM to main container: %v\n", err)
-	}
-}
-
-// getMainContainerPID finds the process ID of the main container
-func getMainContainerPID() (string, error) {
-	out, err := exec.Command("pgrep", "-f", mainContainerName).Output()
-	if err != nil {
-		return "", err
-	}
-
-	// Return the first PID found
-	pidList := strings.Fields(string(out))
-	if len(pidList) > 0 {
-		return pidList[0], nil
-	}
-	return "", fmt.Errorf("main container PID not found")
-}
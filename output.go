@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/hsiaoairplane/vault-operator-helper/internal/output"
+)
+
+var (
+	outputKind     string
+	outputFormat   string
+	outputTemplate string
+)
+
+func init() {
+	flag.StringVar(&outputKind, "output-kind", "configmap", "Where to write the namespace list: configmap or secret")
+	flag.StringVar(&outputFormat, "output-format", "csv", "How to render the namespace list: csv, json, yaml, or gotemplate")
+	flag.StringVar(&outputTemplate, "output-template", "", "Go template used to render the namespace list when --output-format=gotemplate")
+}
+
+// renderNamespaces renders namespaces per --output-format, e.g. Vault's
+// VAULT_K8S_NAMESPACE_SELECTOR JSON form or a YAML list for Helm values.
+func renderNamespaces(namespaces []string) (string, error) {
+	return output.Render(outputFormat, outputTemplate, namespaces)
+}
+
+// writeConfigMap creates or updates the ConfigMap with value, reporting
+// whether the stored value actually changed.
+func writeConfigMap(value string) (bool, error) {
+	return output.WriteConfigMap(context.TODO(), clientset, configMapNamespace, configMapName, watchKey, value)
+}
+
+// writeSecret creates or updates the Secret with value, reporting whether
+// the stored value actually changed.
+func writeSecret(value string) (bool, error) {
+	return output.WriteSecret(context.TODO(), clientset, configMapNamespace, configMapName, watchKey, value)
+}
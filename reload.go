@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	reloadStrategyName string
+	targetKind         string
+	targetNamespace    string
+	targetName         string
+
+	reloader ReloadStrategy
+)
+
+func init() {
+	flag.StringVar(&reloadStrategyName, "reload-strategy", "signal", "How to reload the main container on ConfigMap change: signal, rollout, or annotation")
+	flag.StringVar(&targetKind, "target-kind", "Deployment", "Kind of the workload to reload when --reload-strategy=rollout (Deployment or StatefulSet)")
+	flag.StringVar(&targetNamespace, "target-namespace", "", "Namespace of the workload/pod to reload when --reload-strategy=rollout or annotation")
+	flag.StringVar(&targetName, "target-name", "", "Name of the workload/pod to reload when --reload-strategy=rollout or annotation")
+}
+
+// restartedAtAnnotation is patched with the current time to force a rolling
+// update, mirroring what `kubectl rollout restart` does under the hood.
+const restartedAtAnnotation = "vault-operator-helper/restartedAt"
+
+// ReloadStrategy reloads whatever is consuming the ConfigMap so it picks up
+// the new namespace list.
+type ReloadStrategy interface {
+	Reload(ctx context.Context) error
+}
+
+// newReloadStrategy builds the ReloadStrategy selected via --reload-strategy.
+func newReloadStrategy() (ReloadStrategy, error) {
+	switch reloadStrategyName {
+	case "signal":
+		return &signalReloadStrategy{}, nil
+	case "rollout":
+		if targetNamespace == "" || targetName == "" {
+			return nil, fmt.Errorf("--target-namespace and --target-name are required for --reload-strategy=rollout")
+		}
+		return &rolloutReloadStrategy{}, nil
+	case "annotation":
+		if targetNamespace == "" || targetName == "" {
+			return nil, fmt.Errorf("--target-namespace and --target-name are required for --reload-strategy=annotation")
+		}
+		return &annotationReloadStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown --reload-strategy %q, must be one of: signal, rollout, annotation", reloadStrategyName)
+	}
+}
+
+// signalReloadStrategy is the legacy behavior: find the main container's PID
+// via pgrep (assumes a shared PID namespace) and send it SIGTERM.
+type signalReloadStrategy struct{}
+
+func (s *signalReloadStrategy) Reload(ctx context.Context) error {
+	fmt.Println("Restarting main container...")
+
+	pid, err := getMainContainerPID()
+	if err != nil {
+		return fmt.Errorf("getting main container PID: %w", err)
+	}
+
+	if err := exec.CommandContext(ctx, "kill", "-SIGTERM", pid).Run(); err != nil {
+		return fmt.Errorf("sending SIGTERM to main container: %w", err)
+	}
+	return nil
+}
+
+// getMainContainerPID finds the process ID of the main container
+func getMainContainerPID() (string, error) {
+	out, err := exec.Command("pgrep", "-f", mainContainerName).Output()
+	if err != nil {
+		return "", err
+	}
+
+	// Return the first PID found
+	pidList := strings.Fields(string(out))
+	if len(pidList) > 0 {
+		return pidList[0], nil
+	}
+	return "", fmt.Errorf("main container PID not found")
+}
+
+// rolloutReloadStrategy patches spec.template.metadata.annotations on a
+// Deployment or StatefulSet to trigger a normal, controlled rolling update.
+type rolloutReloadStrategy struct{}
+
+func (r *rolloutReloadStrategy) Reload(ctx context.Context) error {
+	patch := []byte(fmt.Sprintf(
+		`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339),
+	))
+
+	switch targetKind {
+	case "Deployment":
+		_, err := clientset.AppsV1().Deployments(targetNamespace).Patch(ctx, targetName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("patching deployment %s/%s: %w", targetNamespace, targetName, err)
+		}
+	case "StatefulSet":
+		_, err := clientset.AppsV1().StatefulSets(targetNamespace).Patch(ctx, targetName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("patching statefulset %s/%s: %w", targetNamespace, targetName, err)
+		}
+	default:
+		return fmt.Errorf("unsupported --target-kind %q, must be Deployment or StatefulSet", targetKind)
+	}
+
+	fmt.Printf("Triggered rollout restart of %s %s/%s\n", targetKind, targetNamespace, targetName)
+	return nil
+}
+
+// annotationReloadStrategy bumps an annotation directly on the ConfigMap
+// consumer Pod, for setups where the workload itself shouldn't be touched.
+type annotationReloadStrategy struct{}
+
+func (a *annotationReloadStrategy) Reload(ctx context.Context) error {
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+
+	_, err := clientset.CoreV1().Pods(targetNamespace).Patch(ctx, targetName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("patching pod %s/%s: %w", targetNamespace, targetName, err)
+	}
+
+	fmt.Printf("Bumped %s on pod %s/%s\n", restartedAtAnnotation, targetNamespace, targetName)
+	return nil
+}